@@ -0,0 +1,76 @@
+package nestedjson
+
+// deepClone recursively copies maps and slices so the result shares no
+// backing storage with v.
+func deepClone(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, cv := range val {
+			out[k] = deepClone(cv)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, cv := range val {
+			out[i] = deepClone(cv)
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+// toFloat reports whether v is a JSON number (int or float64, since both
+// can appear depending on whether a value came from encoding/json or was
+// constructed in Go) and returns it as a float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// deepEqual recursively compares two decoded JSON values, normalizing
+// numeric types so an int and an equal-valued float64 compare equal.
+func deepEqual(a, b interface{}) bool {
+	if af, aIsNum := toFloat(a); aIsNum {
+		if bf, bIsNum := toFloat(b); bIsNum {
+			return af == bf
+		}
+		return false
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			bvv, exists := bv[k]
+			if !exists || !deepEqual(v, bvv) {
+				return false
+			}
+		}
+		return true
+
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !deepEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		return a == b
+	}
+}
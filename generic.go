@@ -0,0 +1,21 @@
+package nestedjson
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Unmarshal re-marshals the subtree at path and decodes it into out,
+// honoring json struct tags the same way encoding/json normally would.
+func Unmarshal[T any](n *NestedJson, path string, out *T) error {
+	v, err := n.Get(path)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	return json.NewDecoder(&buf).Decode(out)
+}
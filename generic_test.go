@@ -0,0 +1,40 @@
+package nestedjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type person struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	json, err := DecodeStr(`{"user": {"name": "Ada", "age": 30}}`)
+	assert.NoError(t, err)
+
+	var p person
+	err = Unmarshal(json, "user", &p)
+	assert.NoError(t, err)
+	assert.Equal(t, person{Name: "Ada", Age: 30}, p)
+}
+
+func TestUnmarshalSlice(t *testing.T) {
+	json, err := DecodeStr(`{"users": [{"name": "Ada", "age": 30}, {"name": "Bo", "age": 25}]}`)
+	assert.NoError(t, err)
+
+	var people []person
+	err = Unmarshal(json, "users", &people)
+	assert.NoError(t, err)
+	assert.Equal(t, []person{{Name: "Ada", Age: 30}, {Name: "Bo", Age: 25}}, people)
+}
+
+func TestUnmarshalMissingPath(t *testing.T) {
+	json := New()
+
+	var p person
+	err := Unmarshal(json, "missing", &p)
+	assert.Error(t, err)
+}
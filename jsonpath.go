@@ -0,0 +1,390 @@
+package nestedjson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segmentKind identifies which kind of JSONPath segment a pathSegment
+// represents.
+type segmentKind int
+
+const (
+	segChild segmentKind = iota
+	segWildcard
+	segIndex
+	segSlice
+	segRecursive
+	segFilter
+)
+
+// pathSegment is one step of a parsed JSONPath expression.
+type pathSegment struct {
+	kind      segmentKind
+	name      string
+	index     int
+	sliceFrom *int
+	sliceTo   *int
+	sliceStep *int
+	filter    filterExpr
+}
+
+// parseJSONPath parses a Goessner-style JSONPath expression (e.g.
+// "$.a.b[*]", "$..z[?(@.a > 100)].b") into a sequence of segments.
+func parseJSONPath(expr string) ([]pathSegment, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("JSONPath must start with $: %s", expr)
+	}
+
+	pos := 1
+	var segs []pathSegment
+
+	for pos < len(expr) {
+		switch expr[pos] {
+		case '.':
+			if pos+1 < len(expr) && expr[pos+1] == '.' {
+				pos += 2
+				segs = append(segs, pathSegment{kind: segRecursive})
+				if pos < len(expr) && expr[pos] != '[' {
+					seg, next, err := parseDotName(expr, pos)
+					if err != nil {
+						return nil, err
+					}
+					segs = append(segs, *seg)
+					pos = next
+				}
+				continue
+			}
+			pos++
+			seg, next, err := parseDotName(expr, pos)
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, *seg)
+			pos = next
+
+		case '[':
+			seg, next, err := parseBracket(expr, pos)
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, *seg)
+			pos = next
+
+		default:
+			return nil, fmt.Errorf("Invalid JSONPath at pos %d: %s", pos, expr)
+		}
+	}
+
+	return segs, nil
+}
+
+func parseDotName(expr string, pos int) (*pathSegment, int, error) {
+	if pos >= len(expr) {
+		return nil, pos, fmt.Errorf("Invalid JSONPath: unexpected end of expression")
+	}
+	if expr[pos] == '*' {
+		return &pathSegment{kind: segWildcard}, pos + 1, nil
+	}
+
+	start := pos
+	for pos < len(expr) && expr[pos] != '.' && expr[pos] != '[' {
+		pos++
+	}
+	name := expr[start:pos]
+	if name == "" {
+		return nil, pos, fmt.Errorf("Invalid JSONPath: empty name at %d", start)
+	}
+	return &pathSegment{kind: segChild, name: name}, pos, nil
+}
+
+func parseBracket(expr string, pos int) (*pathSegment, int, error) {
+	end, err := findBracketEnd(expr, pos)
+	if err != nil {
+		return nil, pos, err
+	}
+	content := expr[pos+1 : end]
+	next := end + 1
+
+	if strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")") {
+		f, err := parseFilterExpr(content[2 : len(content)-1])
+		if err != nil {
+			return nil, pos, err
+		}
+		return &pathSegment{kind: segFilter, filter: f}, next, nil
+	}
+
+	if content == "*" {
+		return &pathSegment{kind: segWildcard}, next, nil
+	}
+
+	if len(content) >= 2 &&
+		(content[0] == '\'' && content[len(content)-1] == '\'' ||
+			content[0] == '"' && content[len(content)-1] == '"') {
+		return &pathSegment{kind: segChild, name: content[1 : len(content)-1]}, next, nil
+	}
+
+	if strings.Contains(content, ":") {
+		parts := strings.Split(content, ":")
+		if len(parts) > 3 {
+			return nil, pos, fmt.Errorf("Invalid slice: %s", content)
+		}
+		ints := make([]*int, 3)
+		for i, p := range parts {
+			if p == "" {
+				continue
+			}
+			v, err := strconv.Atoi(p)
+			if err != nil {
+				return nil, pos, fmt.Errorf("Invalid slice index: %s", p)
+			}
+			ints[i] = &v
+		}
+		return &pathSegment{kind: segSlice, sliceFrom: ints[0], sliceTo: ints[1], sliceStep: ints[2]}, next, nil
+	}
+
+	i, err := strconv.Atoi(content)
+	if err != nil {
+		return nil, pos, fmt.Errorf("Invalid bracket content: %s", content)
+	}
+	return &pathSegment{kind: segIndex, index: i}, next, nil
+}
+
+// findBracketEnd returns the index of the ']' that closes the '[' at pos,
+// tracking quoted string literals and nested brackets (e.g. a filter
+// predicate referencing "@.arr[0]") so an embedded ']' inside a quoted
+// value or a nested bracket isn't mistaken for the terminator.
+func findBracketEnd(expr string, pos int) (int, error) {
+	depth := 0
+	var quote byte
+	for i := pos + 1; i < len(expr); i++ {
+		c := expr[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '[':
+			depth++
+		case ']':
+			if depth == 0 {
+				return i, nil
+			}
+			depth--
+		}
+	}
+	return -1, fmt.Errorf("Unterminated [ in JSONPath: %s", expr)
+}
+
+// pathValue pairs a matched value with the concrete path it was found at.
+type pathValue struct {
+	value interface{}
+	path  string
+}
+
+func evalSegments(root interface{}, segs []pathSegment) ([]pathValue, error) {
+	working := []pathValue{{value: root, path: "$"}}
+	for _, seg := range segs {
+		var next []pathValue
+		for _, pv := range working {
+			matched, err := applySegment(pv, seg)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, matched...)
+		}
+		working = next
+	}
+	return working, nil
+}
+
+func applySegment(pv pathValue, seg pathSegment) ([]pathValue, error) {
+	switch seg.kind {
+	case segChild:
+		m, ok := pv.value.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		v, ok := m[seg.name]
+		if !ok {
+			return nil, nil
+		}
+		return []pathValue{{value: v, path: pv.path + "." + seg.name}}, nil
+
+	case segWildcard:
+		return directChildren(pv), nil
+
+	case segIndex:
+		arr, ok := pv.value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		i := seg.index
+		if i < 0 {
+			i += len(arr)
+		}
+		if i < 0 || i >= len(arr) {
+			return nil, nil
+		}
+		return []pathValue{{value: arr[i], path: fmt.Sprintf("%s[%d]", pv.path, i)}}, nil
+
+	case segSlice:
+		arr, ok := pv.value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		var out []pathValue
+		from, to, step := resolveSlice(seg, len(arr))
+		if step > 0 {
+			for i := from; i < to; i += step {
+				out = append(out, pathValue{value: arr[i], path: fmt.Sprintf("%s[%d]", pv.path, i)})
+			}
+		} else if step < 0 {
+			for i := from; i > to; i += step {
+				out = append(out, pathValue{value: arr[i], path: fmt.Sprintf("%s[%d]", pv.path, i)})
+			}
+		}
+		return out, nil
+
+	case segRecursive:
+		return collectRecursive(pv), nil
+
+	case segFilter:
+		var out []pathValue
+		for _, c := range directChildren(pv) {
+			result, err := seg.filter.eval(c.value)
+			if err != nil {
+				return nil, err
+			}
+			if truthy(result) {
+				out = append(out, c)
+			}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("Unknown JSONPath segment kind")
+}
+
+func directChildren(pv pathValue) []pathValue {
+	switch v := pv.value.(type) {
+	case []interface{}:
+		out := make([]pathValue, len(v))
+		for i, cv := range v {
+			out[i] = pathValue{value: cv, path: fmt.Sprintf("%s[%d]", pv.path, i)}
+		}
+		return out
+	case map[string]interface{}:
+		out := make([]pathValue, 0, len(v))
+		for k, cv := range v {
+			out = append(out, pathValue{value: cv, path: pv.path + "." + k})
+		}
+		return out
+	}
+	return nil
+}
+
+func collectRecursive(pv pathValue) []pathValue {
+	out := []pathValue{pv}
+	for _, c := range directChildren(pv) {
+		out = append(out, collectRecursive(c)...)
+	}
+	return out
+}
+
+func resolveSlice(seg pathSegment, length int) (from, to, step int) {
+	step = 1
+	if seg.sliceStep != nil {
+		step = *seg.sliceStep
+	}
+
+	if seg.sliceFrom != nil {
+		from = *seg.sliceFrom
+		if from < 0 {
+			from += length
+		}
+	} else if step > 0 {
+		from = 0
+	} else {
+		from = length - 1
+	}
+
+	if seg.sliceTo != nil {
+		to = *seg.sliceTo
+		if to < 0 {
+			to += length
+		}
+	} else if step > 0 {
+		to = length
+	} else {
+		to = -1
+	}
+
+	if step > 0 {
+		if from < 0 {
+			from = 0
+		}
+		if from > length {
+			from = length
+		}
+		if to < 0 {
+			to = 0
+		}
+		if to > length {
+			to = length
+		}
+	} else {
+		if from < -1 {
+			from = -1
+		}
+		if from > length-1 {
+			from = length - 1
+		}
+		if to < -1 {
+			to = -1
+		}
+		if to > length-1 {
+			to = length - 1
+		}
+	}
+	return
+}
+
+// Query evaluates a JSONPath expression and returns the matched values.
+func (n *NestedJson) Query(expr string) ([]interface{}, error) {
+	matches, err := n.queryMatches(expr)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]interface{}, len(matches))
+	for i, m := range matches {
+		out[i] = m.value
+	}
+	return out, nil
+}
+
+// QueryPaths evaluates a JSONPath expression and returns the dot/bracket
+// paths of the matched values instead of the values themselves.
+func (n *NestedJson) QueryPaths(expr string) ([]string, error) {
+	matches, err := n.queryMatches(expr)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(matches))
+	for i, m := range matches {
+		out[i] = m.path
+	}
+	return out, nil
+}
+
+func (n *NestedJson) queryMatches(expr string) ([]pathValue, error) {
+	segs, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	return evalSegments(n.data, segs)
+}
@@ -0,0 +1,404 @@
+package nestedjson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// filterExpr is a node in a JSONPath filter predicate's AST. eval
+// resolves the node against the current element (the value bound to
+// "@") and returns either a bool (for logical/comparison nodes) or a
+// scalar value (for literals and "@" paths).
+type filterExpr interface {
+	eval(elem interface{}) (interface{}, error)
+}
+
+type litNode struct{ val interface{} }
+
+func (l *litNode) eval(elem interface{}) (interface{}, error) { return l.val, nil }
+
+// pathNode resolves a "@.a.b"-style path relative to the current element.
+// A path that doesn't resolve (e.g. a missing key) yields nil rather
+// than an error, so "==" and ordering comparisons against it fail to
+// match, while "!=" against it matches.
+type pathNode struct{ parts []interface{} }
+
+func (p *pathNode) eval(elem interface{}) (interface{}, error) {
+	curr := elem
+	for _, part := range p.parts {
+		v, err := getPart(curr, part, false)
+		if err != nil {
+			return nil, nil
+		}
+		curr = v
+	}
+	return curr, nil
+}
+
+type notNode struct{ operand filterExpr }
+
+func (n *notNode) eval(elem interface{}) (interface{}, error) {
+	v, err := n.operand.eval(elem)
+	if err != nil {
+		return nil, err
+	}
+	return !truthy(v), nil
+}
+
+type logicalNode struct {
+	op          string
+	left, right filterExpr
+}
+
+func (n *logicalNode) eval(elem interface{}) (interface{}, error) {
+	l, err := n.left.eval(elem)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "&&" && !truthy(l) {
+		return false, nil
+	}
+	if n.op == "||" && truthy(l) {
+		return true, nil
+	}
+	r, err := n.right.eval(elem)
+	if err != nil {
+		return nil, err
+	}
+	return truthy(r), nil
+}
+
+type compareNode struct {
+	op          filterTokenKind
+	left, right filterExpr
+}
+
+func (n *compareNode) eval(elem interface{}) (interface{}, error) {
+	l, err := n.left.eval(elem)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(elem)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case ftEq:
+		return deepEqual(l, r), nil
+	case ftNe:
+		return !deepEqual(l, r), nil
+	}
+
+	lf, lok := toFloat(l)
+	rf, rok := toFloat(r)
+	if !lok || !rok {
+		return false, nil
+	}
+	switch n.op {
+	case ftLt:
+		return lf < rf, nil
+	case ftLe:
+		return lf <= rf, nil
+	case ftGt:
+		return lf > rf, nil
+	case ftGe:
+		return lf >= rf, nil
+	}
+	return false, nil
+}
+
+func truthy(v interface{}) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case nil:
+		return false
+	default:
+		return true
+	}
+}
+
+func parseAtPath(s string) ([]interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if s[0] == '.' {
+		s = s[1:]
+	}
+	if s == "" {
+		return nil, nil
+	}
+	return splitPath(s)
+}
+
+// filterTokenKind enumerates the lexical tokens understood inside a
+// "[?( ... )]" filter predicate.
+type filterTokenKind int
+
+const (
+	ftEOF filterTokenKind = iota
+	ftAnd
+	ftOr
+	ftNot
+	ftEq
+	ftNe
+	ftLe
+	ftGe
+	ftLt
+	ftGt
+	ftLParen
+	ftRParen
+	ftNumber
+	ftString
+	ftBool
+	ftPath
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	num  float64
+	b    bool
+}
+
+func lexFilter(s string) ([]filterToken, error) {
+	var toks []filterToken
+	i := 0
+
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+
+		case c == '(':
+			toks = append(toks, filterToken{kind: ftLParen})
+			i++
+
+		case c == ')':
+			toks = append(toks, filterToken{kind: ftRParen})
+			i++
+
+		case strings.HasPrefix(s[i:], "&&"):
+			toks = append(toks, filterToken{kind: ftAnd})
+			i += 2
+
+		case strings.HasPrefix(s[i:], "||"):
+			toks = append(toks, filterToken{kind: ftOr})
+			i += 2
+
+		case strings.HasPrefix(s[i:], "=="):
+			toks = append(toks, filterToken{kind: ftEq})
+			i += 2
+
+		case strings.HasPrefix(s[i:], "!="):
+			toks = append(toks, filterToken{kind: ftNe})
+			i += 2
+
+		case strings.HasPrefix(s[i:], "<="):
+			toks = append(toks, filterToken{kind: ftLe})
+			i += 2
+
+		case strings.HasPrefix(s[i:], ">="):
+			toks = append(toks, filterToken{kind: ftGe})
+			i += 2
+
+		case c == '<':
+			toks = append(toks, filterToken{kind: ftLt})
+			i++
+
+		case c == '>':
+			toks = append(toks, filterToken{kind: ftGt})
+			i++
+
+		case c == '!':
+			toks = append(toks, filterToken{kind: ftNot})
+			i++
+
+		case c == '@':
+			start := i
+			i++
+			for i < len(s) && (s[i] == '.' || s[i] == '[' || s[i] == ']' || s[i] == '\'' || isPathChar(s[i])) {
+				i++
+			}
+			toks = append(toks, filterToken{kind: ftPath, text: s[start:i]})
+
+		case c == '\'' || c == '"':
+			quote := c
+			start := i + 1
+			i++
+			for i < len(s) && s[i] != quote {
+				i++
+			}
+			if i >= len(s) {
+				return nil, fmt.Errorf("Unterminated string in filter: %s", s)
+			}
+			toks = append(toks, filterToken{kind: ftString, text: s[start:i]})
+			i++
+
+		case c == '-' || (c >= '0' && c <= '9'):
+			start := i
+			i++
+			for i < len(s) && (s[i] == '.' || (s[i] >= '0' && s[i] <= '9')) {
+				i++
+			}
+			v, err := strconv.ParseFloat(s[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid number in filter: %s", s[start:i])
+			}
+			toks = append(toks, filterToken{kind: ftNumber, num: v})
+
+		case strings.HasPrefix(s[i:], "true"):
+			toks = append(toks, filterToken{kind: ftBool, b: true})
+			i += 4
+
+		case strings.HasPrefix(s[i:], "false"):
+			toks = append(toks, filterToken{kind: ftBool, b: false})
+			i += 5
+
+		default:
+			return nil, fmt.Errorf("Unexpected character in filter at %d: %s", i, s)
+		}
+	}
+
+	toks = append(toks, filterToken{kind: ftEOF})
+	return toks, nil
+}
+
+func isPathChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_'
+}
+
+// filterParser is a small recursive-descent parser (one method per
+// precedence level) over the token stream produced by lexFilter, with
+// precedence || < && < unary ! < comparisons.
+type filterParser struct {
+	toks []filterToken
+	pos  int
+}
+
+func parseFilterExpr(s string) (filterExpr, error) {
+	toks, err := lexFilter(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{toks: toks}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != ftEOF {
+		return nil, fmt.Errorf("Unexpected token in filter: %s", s)
+	}
+	return expr, nil
+}
+
+func (p *filterParser) peek() filterToken { return p.toks[p.pos] }
+
+func (p *filterParser) next() filterToken {
+	t := p.toks[p.pos]
+	p.pos++
+	return t
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == ftOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == ftAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpr, error) {
+	if p.peek().kind == ftNot {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterExpr, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	switch p.peek().kind {
+	case ftEq, ftNe, ftLt, ftLe, ftGt, ftGe:
+		op := p.next().kind
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return &compareNode{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *filterParser) parsePrimary() (filterExpr, error) {
+	t := p.peek()
+	switch t.kind {
+	case ftLParen:
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != ftRParen {
+			return nil, fmt.Errorf("Expected ) in filter expression")
+		}
+		p.next()
+		return expr, nil
+
+	case ftNumber:
+		p.next()
+		return &litNode{val: t.num}, nil
+
+	case ftString:
+		p.next()
+		return &litNode{val: t.text}, nil
+
+	case ftBool:
+		p.next()
+		return &litNode{val: t.b}, nil
+
+	case ftPath:
+		p.next()
+		parts, err := parseAtPath(t.text[1:])
+		if err != nil {
+			return nil, err
+		}
+		return &pathNode{parts: parts}, nil
+	}
+
+	return nil, fmt.Errorf("Unexpected token in filter expression")
+}
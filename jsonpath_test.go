@@ -0,0 +1,83 @@
+package nestedjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryChildAndWildcard(t *testing.T) {
+	json := getTestJson(t, "complex")
+
+	v, err := json.Query("$.a.b.c.g.z[*].a")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []interface{}{"hello", 100.12, 1.0}, v)
+}
+
+func TestQueryRecursiveDescentWithFilter(t *testing.T) {
+	json := getTestJson(t, "complex")
+
+	v, err := json.Query("$..z[?(@.a > 100)].b")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{200.24}, v)
+}
+
+func TestQuerySlice(t *testing.T) {
+	json := getTestJson(t, "complex")
+
+	v, err := json.Query("$.a.b.c.h[0:2]")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		[]interface{}{1.0, 2.0, 3.0},
+		[]interface{}{"a", "b", "c"},
+	}, v)
+}
+
+func TestQuerySliceOutOfRangeBounds(t *testing.T) {
+	json := getTestJson(t, "complex")
+
+	v, err := json.Query("$.a.b.c.h[0][2:-100:-1]")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{3.0, 2.0, 1.0}, v)
+
+	v, err = json.Query("$.a.b.c.h[0][100:-1:-1]")
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+}
+
+func TestQueryFilterLogicalOperators(t *testing.T) {
+	json := getTestJson(t, "complex")
+
+	v, err := json.Query("$.a.b.c.g.z[?(@.a == 1 && @.b == 2)].c")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"go rocks"}, v)
+
+	v, err = json.Query("$.a.b.c.g.z[?(@.a == \"hello\" || @.a > 50)].b")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []interface{}{"world", 200.24}, v)
+}
+
+func TestQueryFilterStringValueWithBracket(t *testing.T) {
+	json := getTestJson(t, "complex")
+
+	v, err := json.Query(`$.a.b.c.g.z[?(@.a == "x]y")].b`)
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+}
+
+func TestQueryPaths(t *testing.T) {
+	json := getTestJson(t, "complex")
+
+	paths, err := json.QueryPaths("$.a.b.c.g.x[*]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"$.a.b.c.g.x[0]", "$.a.b.c.g.x[1]", "$.a.b.c.g.x[2]",
+	}, paths)
+}
+
+func TestQueryInvalidExpression(t *testing.T) {
+	json := getTestJson(t, "complex")
+
+	_, err := json.Query("a.b.c")
+	assert.Error(t, err)
+}
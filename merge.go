@@ -0,0 +1,80 @@
+package nestedjson
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Clone returns a deep copy of the document; mutating the copy does not
+// affect the original.
+func (n *NestedJson) Clone() *NestedJson {
+	return &NestedJson{data: deepClone(n.data).(map[string]interface{})}
+}
+
+// Equal reports whether n and other hold structurally equal values,
+// normalizing int/float64 so the distinction between Go-constructed and
+// decoded-from-JSON documents doesn't affect the comparison.
+func (n *NestedJson) Equal(other *NestedJson) bool {
+	return deepEqual(n.data, other.data)
+}
+
+func mergeValues(a, b interface{}) interface{} {
+	am, aIsMap := a.(map[string]interface{})
+	bm, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		for k, bv := range bm {
+			if av, exists := am[k]; exists {
+				am[k] = mergeValues(av, bv)
+			} else {
+				am[k] = deepClone(bv)
+			}
+		}
+		return am
+	}
+	return deepClone(b)
+}
+
+// Merge recursively overlays other's object keys onto n, replacing any
+// non-object leaf with other's value.
+func (n *NestedJson) Merge(other *NestedJson) error {
+	n.data = mergeValues(n.data, other.data).(map[string]interface{})
+	return nil
+}
+
+func mergePatchValue(target, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return deepClone(patch)
+	}
+
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = make(map[string]interface{})
+	}
+	for k, pv := range patchMap {
+		if pv == nil {
+			delete(targetMap, k)
+			continue
+		}
+		targetMap[k] = mergePatchValue(targetMap[k], pv)
+	}
+	return targetMap
+}
+
+// MergePatch applies an RFC 7396 JSON Merge Patch: a null in the patch
+// removes the corresponding key, an object value merges recursively, and
+// any other value replaces the target outright.
+func (n *NestedJson) MergePatch(patch []byte) error {
+	var p map[string]interface{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return err
+	}
+
+	merged := mergePatchValue(n.data, p)
+	m, ok := merged.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Merge patch result is not an object: %T", merged)
+	}
+	n.data = m
+	return nil
+}
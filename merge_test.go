@@ -0,0 +1,85 @@
+package nestedjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	json := getTestJson(t, "s2")
+	clone := json.Clone()
+
+	assert.True(t, json.Equal(clone))
+
+	err := clone.Set("a.b", "changed")
+	assert.NoError(t, err)
+
+	v, err := json.String("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, "moo", v)
+
+	assert.False(t, json.Equal(clone))
+}
+
+func TestEqual(t *testing.T) {
+	a, err := DecodeStr(`{"a": 1, "b": [1, 2, 3]}`)
+	assert.NoError(t, err)
+	b := New()
+	b.Set("a", 1)
+	b.Set("b", []interface{}{1, 2, 3})
+
+	assert.True(t, a.Equal(b))
+
+	err = b.Set("a", 2)
+	assert.NoError(t, err)
+	assert.False(t, a.Equal(b))
+}
+
+func TestMerge(t *testing.T) {
+	a, err := DecodeStr(`{"a": {"x": 1, "y": 2}, "b": 1}`)
+	assert.NoError(t, err)
+	b, err := DecodeStr(`{"a": {"y": 3, "z": 4}, "b": [1, 2]}`)
+	assert.NoError(t, err)
+
+	err = a.Merge(b)
+	assert.NoError(t, err)
+
+	v, err := a.Int("a.x")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	v, err = a.Int("a.y")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	v, err = a.Int("a.z")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, v)
+
+	arr, err := a.Array("b")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1.0, 2.0}, arr)
+}
+
+func TestMergePatch(t *testing.T) {
+	json, err := DecodeStr(`{"a": {"x": 1, "y": 2}, "b": 1}`)
+	assert.NoError(t, err)
+
+	err = json.MergePatch([]byte(`{"a": {"y": null, "z": 3}, "b": "replaced"}`))
+	assert.NoError(t, err)
+
+	assert.False(t, json.Has("a.y"))
+
+	v, err := json.Int("a.x")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	v, err = json.Int("a.z")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	s, err := json.String("b")
+	assert.NoError(t, err)
+	assert.Equal(t, "replaced", s)
+}
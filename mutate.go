@@ -0,0 +1,136 @@
+package nestedjson
+
+import "fmt"
+
+// Has reports whether path resolves to a value in the document.
+func (n *NestedJson) Has(path string) bool {
+	_, err := n.Get(path)
+	return err == nil
+}
+
+// deletePathRecursive mirrors getPart's traversal but removes the final
+// part instead of reading it, bubbling the (possibly resized, for
+// arrays) container back up to its parent.
+func deletePathRecursive(curr interface{}, parts []interface{}) (interface{}, error) {
+	part := parts[0]
+	rest := parts[1:]
+
+	switch p := part.(type) {
+	case int:
+		arr, ok := curr.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Not an array: %s", curr)
+		}
+		if p < 0 || p >= len(arr) {
+			return nil, fmt.Errorf("Array index out of bounds: %d", p)
+		}
+		if len(rest) == 0 {
+			return append(arr[:p], arr[p+1:]...), nil
+		}
+		updated, err := deletePathRecursive(arr[p], rest)
+		if err != nil {
+			return nil, err
+		}
+		arr[p] = updated
+		return arr, nil
+
+	case string:
+		m, ok := curr.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Not an object: %s", curr)
+		}
+		if len(rest) == 0 {
+			if _, exists := m[p]; !exists {
+				return nil, fmt.Errorf("Key does not exist: %s", p)
+			}
+			delete(m, p)
+			return m, nil
+		}
+		child, exists := m[p]
+		if !exists {
+			return nil, fmt.Errorf("Key does not exist: %s", p)
+		}
+		updated, err := deletePathRecursive(child, rest)
+		if err != nil {
+			return nil, err
+		}
+		m[p] = updated
+		return m, nil
+	}
+
+	return nil, fmt.Errorf("Invalid Part: %T", part)
+}
+
+// Delete removes the value at path, shrinking the containing array or
+// dropping the containing object key as appropriate.
+func (n *NestedJson) Delete(path string) error {
+	parts, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+
+	updated, err := deletePathRecursive(n.data, parts)
+	if err != nil {
+		return err
+	}
+	n.data = updated.(map[string]interface{})
+	return nil
+}
+
+// ArrayAppend appends vals to the array at path. If the final path
+// segment names a key that doesn't exist yet, it is auto-created as
+// []interface{}, matching how Set auto-creates missing nested objects.
+func (n *NestedJson) ArrayAppend(path string, vals ...interface{}) error {
+	parts, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+
+	var curr interface{} = n.data
+	for _, part := range parts[:len(parts)-1] {
+		curr, err = getPart(curr, part, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	key, ok := parts[len(parts)-1].(string)
+	if !ok {
+		return fmt.Errorf("ArrayAppend requires an object key as the final path segment")
+	}
+	m, ok := curr.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Not an object: %s", curr)
+	}
+
+	existing, exists := m[key]
+	if !exists {
+		m[key] = append([]interface{}{}, vals...)
+		return nil
+	}
+	arr, ok := existing.([]interface{})
+	if !ok {
+		return fmt.Errorf("Not an array: %s", existing)
+	}
+	m[key] = append(arr, vals...)
+	return nil
+}
+
+// ArrayInsert inserts val into the array at path at the given index,
+// shifting later elements up by one.
+func (n *NestedJson) ArrayInsert(path string, index int, val interface{}) error {
+	arr, err := n.Array(path)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index > len(arr) {
+		return fmt.Errorf("Array index out of bounds: %d", index)
+	}
+
+	newArr := make([]interface{}, 0, len(arr)+1)
+	newArr = append(newArr, arr[:index]...)
+	newArr = append(newArr, val)
+	newArr = append(newArr, arr[index:]...)
+
+	return n.Set(path, newArr)
+}
@@ -0,0 +1,71 @@
+package nestedjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHas(t *testing.T) {
+	json := getTestJson(t, "s2")
+
+	assert.True(t, json.Has("a.b"))
+	assert.True(t, json.Has("c[0]"))
+	assert.False(t, json.Has("a.missing"))
+	assert.False(t, json.Has("c[10]"))
+}
+
+func TestDeleteObjectKey(t *testing.T) {
+	json := getTestJson(t, "s2")
+
+	err := json.Delete("a.b")
+	assert.NoError(t, err)
+	assert.False(t, json.Has("a.b"))
+	assert.True(t, json.Has("a.c"))
+
+	err = json.Delete("missing")
+	assert.Error(t, err)
+}
+
+func TestDeleteArrayIndex(t *testing.T) {
+	json := getTestJson(t, "s2")
+
+	err := json.Delete("c[1]")
+	assert.NoError(t, err)
+
+	v, err := json.Array("c")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1.0, 3.0}, v)
+}
+
+func TestArrayAppend(t *testing.T) {
+	json := New()
+
+	err := json.ArrayAppend("a.b", 1, 2)
+	assert.NoError(t, err)
+
+	v, err := json.Array("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, v)
+
+	err = json.ArrayAppend("a.b", 3)
+	assert.NoError(t, err)
+
+	v, err = json.Array("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3}, v)
+}
+
+func TestArrayInsert(t *testing.T) {
+	json := getTestJson(t, "s2")
+
+	err := json.ArrayInsert("c", 1, 99)
+	assert.NoError(t, err)
+
+	v, err := json.Array("c")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1.0, 99, 2.0, 3.0}, v)
+
+	err = json.ArrayInsert("c", 10, 1)
+	assert.Error(t, err)
+}
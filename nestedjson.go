@@ -326,3 +326,45 @@ func (n *NestedJson) Map(path string) (map[string]interface{}, error) {
 		return nil, fmt.Errorf("%s is not a map", path, o)
 	}
 }
+
+func (n *NestedJson) StringOr(path, def string) string {
+	if v, err := n.String(path); err == nil {
+		return v
+	}
+	return def
+}
+
+func (n *NestedJson) IntOr(path string, def int) int {
+	if v, err := n.Int(path); err == nil {
+		return v
+	}
+	return def
+}
+
+func (n *NestedJson) FloatOr(path string, def float64) float64 {
+	if v, err := n.Float(path); err == nil {
+		return v
+	}
+	return def
+}
+
+func (n *NestedJson) BoolOr(path string, def bool) bool {
+	if v, err := n.Bool(path); err == nil {
+		return v
+	}
+	return def
+}
+
+func (n *NestedJson) ArrayOr(path string, def []interface{}) []interface{} {
+	if v, err := n.Array(path); err == nil {
+		return v
+	}
+	return def
+}
+
+func (n *NestedJson) MapOr(path string, def map[string]interface{}) map[string]interface{} {
+	if v, err := n.Map(path); err == nil {
+		return v
+	}
+	return def
+}
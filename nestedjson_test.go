@@ -235,3 +235,28 @@ func TestSetExisting(t *testing.T) {
 			`"b":[1,2,3,4,5],"c":["xxx",2,3],"d":[[0,1],`+
 			`{"a":"zzz"},[{"b":2},{"c":3}]]}`)
 }
+
+func TestTypedGettersOr(t *testing.T) {
+	json := getTestJson(t, "s1")
+
+	assert.Equal(t, "moo", json.StringOr("b", "fallback"))
+	assert.Equal(t, "fallback", json.StringOr("missing", "fallback"))
+
+	assert.Equal(t, 1, json.IntOr("a", 99))
+	assert.Equal(t, 99, json.IntOr("missing", 99))
+
+	assert.Equal(t, 1.2, json.FloatOr("d", 9.9))
+	assert.Equal(t, 9.9, json.FloatOr("missing", 9.9))
+
+	assert.Equal(t, true, json.BoolOr("c", false))
+	assert.Equal(t, false, json.BoolOr("missing", false))
+
+	complex := getTestJson(t, "complex")
+	assert.Equal(t, []interface{}{"cow", "dog", "bird"},
+		complex.ArrayOr("a.b.c.f", nil))
+	assert.Nil(t, complex.ArrayOr("missing", nil))
+
+	assert.Equal(t, map[string]interface{}{"a": "hello", "b": "world"},
+		complex.MapOr("a.b.c.g.z[0]", nil))
+	assert.Nil(t, complex.MapOr("missing", nil))
+}
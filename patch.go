@@ -0,0 +1,239 @@
+package nestedjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// patchOp is a single RFC 6902 JSON Patch operation.
+type patchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ApplyPatch applies an RFC 6902 JSON Patch document. All operations are
+// applied to a deep clone of the document so that if any operation fails
+// (including a failed "test"), the document is left completely
+// untouched.
+func (n *NestedJson) ApplyPatch(patch []byte) error {
+	var ops []patchOp
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return err
+	}
+
+	working := &NestedJson{deepClone(n.data).(map[string]interface{})}
+	for _, op := range ops {
+		if err := applyPatchOp(working, op); err != nil {
+			return err
+		}
+	}
+
+	n.data = working.data
+	return nil
+}
+
+func applyPatchOp(doc *NestedJson, op patchOp) error {
+	switch op.Op {
+	case "add":
+		return patchAdd(doc, op.Path, op.Value)
+
+	case "remove":
+		return doc.DeletePointer(op.Path)
+
+	case "replace":
+		if _, err := doc.GetPointer(op.Path); err != nil {
+			return fmt.Errorf("replace target does not exist: %s", op.Path)
+		}
+		return doc.SetPointer(op.Path, op.Value)
+
+	case "move":
+		if isPointerPrefix(op.From, op.Path) {
+			return fmt.Errorf("move from %s to %s: from is a prefix of path", op.From, op.Path)
+		}
+		val, err := doc.GetPointer(op.From)
+		if err != nil {
+			return err
+		}
+		if err := doc.DeletePointer(op.From); err != nil {
+			return err
+		}
+		return patchAdd(doc, op.Path, deepClone(val))
+
+	case "copy":
+		val, err := doc.GetPointer(op.From)
+		if err != nil {
+			return err
+		}
+		return patchAdd(doc, op.Path, deepClone(val))
+
+	case "test":
+		val, err := doc.GetPointer(op.Path)
+		if err != nil {
+			return err
+		}
+		if !deepEqual(val, op.Value) {
+			return fmt.Errorf("test failed at %s: %v != %v", op.Path, val, op.Value)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("Unknown patch operation: %s", op.Op)
+	}
+}
+
+// patchAdd implements the "add" insertion semantics of RFC 6902: into an
+// array it inserts at the given index (or appends for "-"), into an
+// object it creates or replaces the key.
+func patchAdd(doc *NestedJson, ptr string, val interface{}) error {
+	tokens, err := splitPointer(ptr)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Root value must be an object: %T", val)
+		}
+		doc.data = m
+		return nil
+	}
+
+	updated, err := addPointerRecursive(doc.data, tokens, val)
+	if err != nil {
+		return err
+	}
+	doc.data = updated.(map[string]interface{})
+	return nil
+}
+
+func addPointerRecursive(curr interface{}, tokens []string, val interface{}) (interface{}, error) {
+	token := tokens[0]
+	rest := tokens[1:]
+
+	if arr, ok := curr.([]interface{}); ok {
+		if len(rest) == 0 {
+			if token == "-" {
+				return append(arr, val), nil
+			}
+			i, err := parseArrayIndex(token, len(arr)+1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, nil)
+			copy(arr[i+1:], arr[i:])
+			arr[i] = val
+			return arr, nil
+		}
+
+		i, err := parseArrayIndex(token, len(arr))
+		if err != nil {
+			return nil, err
+		}
+		updated, err := addPointerRecursive(arr[i], rest, val)
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = updated
+		return arr, nil
+	}
+
+	m, ok := curr.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v is not an object or array: %T", curr, curr)
+	}
+	if len(rest) == 0 {
+		m[token] = val
+		return m, nil
+	}
+
+	child, exists := m[token]
+	if !exists {
+		child = make(map[string]interface{})
+	}
+	updated, err := addPointerRecursive(child, rest, val)
+	if err != nil {
+		return nil, err
+	}
+	m[token] = updated
+	return m, nil
+}
+
+// isPointerPrefix reports whether from is a proper prefix of path (i.e.
+// path addresses one of from's descendants, not from itself), which RFC 6902
+// §4.4 forbids for "move" since a value cannot be moved into its own
+// children.
+func isPointerPrefix(from, path string) bool {
+	if from == path {
+		return false
+	}
+	return strings.HasPrefix(path, from+"/")
+}
+
+func parseArrayIndex(token string, limit int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i >= limit {
+		return 0, fmt.Errorf("Array index out of bounds: %s", token)
+	}
+	return i, nil
+}
+
+// Diff produces a minimal RFC 6902 JSON Patch that transforms a into b by
+// walking both trees in parallel.
+func Diff(a, b *NestedJson) ([]byte, error) {
+	ops := diffValues("", a.data, b.data)
+	if ops == nil {
+		ops = []patchOp{}
+	}
+	return json.Marshal(ops)
+}
+
+func diffValues(path string, a, b interface{}) []patchOp {
+	am, aIsMap := a.(map[string]interface{})
+	bm, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		var ops []patchOp
+		for k, av := range am {
+			p := path + "/" + escapePointerToken(k)
+			if bv, exists := bm[k]; exists {
+				ops = append(ops, diffValues(p, av, bv)...)
+			} else {
+				ops = append(ops, patchOp{Op: "remove", Path: p})
+			}
+		}
+		for k, bv := range bm {
+			if _, exists := am[k]; !exists {
+				ops = append(ops, patchOp{Op: "add", Path: path + "/" + escapePointerToken(k), Value: bv})
+			}
+		}
+		return ops
+	}
+
+	aa, aIsArr := a.([]interface{})
+	ba, bIsArr := b.([]interface{})
+	if aIsArr && bIsArr {
+		var ops []patchOp
+		minLen := len(aa)
+		if len(ba) < minLen {
+			minLen = len(ba)
+		}
+		for i := 0; i < minLen; i++ {
+			ops = append(ops, diffValues(fmt.Sprintf("%s/%d", path, i), aa[i], ba[i])...)
+		}
+		for i := len(aa) - 1; i >= minLen; i-- {
+			ops = append(ops, patchOp{Op: "remove", Path: fmt.Sprintf("%s/%d", path, i)})
+		}
+		for i := minLen; i < len(ba); i++ {
+			ops = append(ops, patchOp{Op: "add", Path: fmt.Sprintf("%s/%d", path, i), Value: ba[i]})
+		}
+		return ops
+	}
+
+	if !deepEqual(a, b) {
+		return []patchOp{{Op: "replace", Path: path, Value: b}}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package nestedjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPatchAddReplaceRemove(t *testing.T) {
+	json, err := DecodeStr(`{"a": {"b": 1}, "arr": [1, 2, 3]}`)
+	assert.NoError(t, err)
+
+	patch := []byte(`[
+		{"op": "replace", "path": "/a/b", "value": 2},
+		{"op": "add", "path": "/a/c", "value": 3},
+		{"op": "add", "path": "/arr/1", "value": 99},
+		{"op": "remove", "path": "/arr/0"}
+	]`)
+
+	err = json.ApplyPatch(patch)
+	assert.NoError(t, err)
+
+	v, err := json.GetPointer("/a/b")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, v)
+
+	v, err = json.GetPointer("/a/c")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, v)
+
+	v, err = json.GetPointer("/arr")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{99.0, 2.0, 3.0}, v)
+}
+
+func TestApplyPatchReplaceArrayElement(t *testing.T) {
+	json, err := DecodeStr(`{"arr": [1, 2, 3]}`)
+	assert.NoError(t, err)
+
+	patch := []byte(`[{"op": "replace", "path": "/arr/1", "value": 99}]`)
+
+	err = json.ApplyPatch(patch)
+	assert.NoError(t, err)
+
+	v, err := json.GetPointer("/arr")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1.0, 99.0, 3.0}, v)
+}
+
+func TestApplyPatchMoveIntoOwnDescendantFails(t *testing.T) {
+	json, err := DecodeStr(`{"a": {"b": 1}}`)
+	assert.NoError(t, err)
+
+	patch := []byte(`[{"op": "move", "from": "/a", "path": "/a/b"}]`)
+
+	err = json.ApplyPatch(patch)
+	assert.Error(t, err)
+
+	v, err := json.GetPointer("/a/b")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, v)
+}
+
+func TestApplyPatchMoveCopyTest(t *testing.T) {
+	json, err := DecodeStr(`{"a": {"b": 1}}`)
+	assert.NoError(t, err)
+
+	patch := []byte(`[
+		{"op": "test", "path": "/a/b", "value": 1},
+		{"op": "copy", "from": "/a/b", "path": "/a/c"},
+		{"op": "move", "from": "/a/b", "path": "/a/d"}
+	]`)
+
+	err = json.ApplyPatch(patch)
+	assert.NoError(t, err)
+
+	_, err = json.GetPointer("/a/b")
+	assert.Error(t, err)
+
+	v, err := json.GetPointer("/a/c")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, v)
+
+	v, err = json.GetPointer("/a/d")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, v)
+}
+
+func TestApplyPatchFailureLeavesDocumentUntouched(t *testing.T) {
+	json, err := DecodeStr(`{"a": {"b": 1}}`)
+	assert.NoError(t, err)
+
+	patch := []byte(`[
+		{"op": "replace", "path": "/a/b", "value": 2},
+		{"op": "test", "path": "/a/b", "value": 999}
+	]`)
+
+	err = json.ApplyPatch(patch)
+	assert.Error(t, err)
+
+	v, err := json.GetPointer("/a/b")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, v)
+}
+
+func TestDiffReplaceAtArrayIndex(t *testing.T) {
+	aJSON := `{"d": [1, 2, 3]}`
+	bJSON := `{"d": [1, 99, 3]}`
+
+	a, err := DecodeStr(aJSON)
+	assert.NoError(t, err)
+	b, err := DecodeStr(bJSON)
+	assert.NoError(t, err)
+
+	patchBytes, err := Diff(a, b)
+	assert.NoError(t, err)
+
+	patched, err := DecodeStr(aJSON)
+	assert.NoError(t, err)
+	err = patched.ApplyPatch(patchBytes)
+	assert.NoError(t, err)
+
+	v, err := patched.GetPointer("/d")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1.0, 99.0, 3.0}, v)
+}
+
+func TestDiff(t *testing.T) {
+	aJSON := `{"a": 1, "b": {"c": 2}, "d": [1, 2, 3]}`
+	bJSON := `{"a": 1, "b": {"c": 3}, "d": [1, 2], "e": 4}`
+
+	a, err := DecodeStr(aJSON)
+	assert.NoError(t, err)
+	b, err := DecodeStr(bJSON)
+	assert.NoError(t, err)
+
+	patchBytes, err := Diff(a, b)
+	assert.NoError(t, err)
+
+	patched, err := DecodeStr(aJSON)
+	assert.NoError(t, err)
+	err = patched.ApplyPatch(patchBytes)
+	assert.NoError(t, err)
+
+	patchedStr, err := patched.EncodeStr()
+	assert.NoError(t, err)
+	bStr, err := b.EncodeStr()
+	assert.NoError(t, err)
+	assert.Equal(t, bStr, patchedStr)
+}
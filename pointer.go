@@ -0,0 +1,225 @@
+package nestedjson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitPointer tokenizes an RFC 6901 JSON Pointer into its raw (unescaped)
+// reference tokens. The root pointer "" yields no tokens.
+func splitPointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("Invalid JSON Pointer: %s", ptr)
+	}
+
+	raw := strings.Split(ptr[1:], "/")
+	tokens := make([]string, len(raw))
+	for i, t := range raw {
+		t = strings.ReplaceAll(t, "~1", "/")
+		t = strings.ReplaceAll(t, "~0", "~")
+		tokens[i] = t
+	}
+	return tokens, nil
+}
+
+// escapePointerToken escapes a raw key so it can be embedded as a single
+// RFC 6901 reference token.
+func escapePointerToken(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+	return token
+}
+
+// getPointerPart resolves a single reference token against obj. A
+// numeric-looking token is only treated as an array index when obj is
+// actually an array; against an object it is looked up as a plain string
+// key, since pointer keys can legitimately be "0".
+func getPointerPart(obj interface{}, token string, createMissingObject bool) (interface{}, error) {
+	if arr, ok := obj.([]interface{}); ok {
+		if token == "-" {
+			return nil, fmt.Errorf("Array index out of bounds: -")
+		}
+		i, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid array index: %s", token)
+		}
+		if i < 0 || i >= len(arr) {
+			return nil, fmt.Errorf("Array index out of bounds: %d", i)
+		}
+		return arr[i], nil
+	}
+
+	if m, ok := obj.(map[string]interface{}); ok {
+		if rv, ok := m[token]; ok {
+			return rv, nil
+		}
+		if createMissingObject {
+			rv := make(map[string]interface{})
+			m[token] = rv
+			return rv, nil
+		}
+		return nil, fmt.Errorf("Key does not exist: %s", token)
+	}
+
+	return nil, fmt.Errorf("%v is not an object or array: %T", obj, obj)
+}
+
+// GetPointer resolves an RFC 6901 JSON Pointer against the document. The
+// root pointer "" returns the whole document.
+func (n *NestedJson) GetPointer(ptr string) (interface{}, error) {
+	tokens, err := splitPointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	var curr interface{} = n.data
+	for _, token := range tokens {
+		curr, err = getPointerPart(curr, token, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return curr, nil
+}
+
+// setPointerRecursive walks down to the location addressed by tokens,
+// setting val there, and returns the (possibly new, for arrays) container
+// at each level so callers can splice the result back into their parent.
+func setPointerRecursive(curr interface{}, tokens []string, val interface{}) (interface{}, error) {
+	token := tokens[0]
+	rest := tokens[1:]
+
+	if arr, ok := curr.([]interface{}); ok {
+		if len(rest) == 0 {
+			if token == "-" {
+				return append(arr, val), nil
+			}
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(arr) {
+				return nil, fmt.Errorf("Array index out of bounds: %s", token)
+			}
+			arr[i] = val
+			return arr, nil
+		}
+
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(arr) {
+			return nil, fmt.Errorf("Array index out of bounds: %s", token)
+		}
+		updated, err := setPointerRecursive(arr[i], rest, val)
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = updated
+		return arr, nil
+	}
+
+	m, ok := curr.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v is not an object or array: %T", curr, curr)
+	}
+	if len(rest) == 0 {
+		m[token] = val
+		return m, nil
+	}
+
+	child, exists := m[token]
+	if !exists {
+		child = make(map[string]interface{})
+	}
+	updated, err := setPointerRecursive(child, rest, val)
+	if err != nil {
+		return nil, err
+	}
+	m[token] = updated
+	return m, nil
+}
+
+// SetPointer sets the value addressed by an RFC 6901 JSON Pointer,
+// auto-creating missing intermediate objects the same way Set does. A
+// trailing "-" token appends to the target array.
+func (n *NestedJson) SetPointer(ptr string, val interface{}) error {
+	tokens, err := splitPointer(ptr)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Root value must be an object: %T", val)
+		}
+		n.data = m
+		return nil
+	}
+
+	updated, err := setPointerRecursive(n.data, tokens, val)
+	if err != nil {
+		return err
+	}
+	n.data = updated.(map[string]interface{})
+	return nil
+}
+
+// deletePointerRecursive mirrors setPointerRecursive but removes the
+// location addressed by tokens instead of assigning to it.
+func deletePointerRecursive(curr interface{}, tokens []string) (interface{}, error) {
+	token := tokens[0]
+	rest := tokens[1:]
+
+	if arr, ok := curr.([]interface{}); ok {
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(arr) {
+			return nil, fmt.Errorf("Array index out of bounds: %s", token)
+		}
+		if len(rest) == 0 {
+			return append(arr[:i], arr[i+1:]...), nil
+		}
+		updated, err := deletePointerRecursive(arr[i], rest)
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = updated
+		return arr, nil
+	}
+
+	m, ok := curr.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v is not an object or array: %T", curr, curr)
+	}
+	child, exists := m[token]
+	if !exists {
+		return nil, fmt.Errorf("Key does not exist: %s", token)
+	}
+	if len(rest) == 0 {
+		delete(m, token)
+		return m, nil
+	}
+	updated, err := deletePointerRecursive(child, rest)
+	if err != nil {
+		return nil, err
+	}
+	m[token] = updated
+	return m, nil
+}
+
+// DeletePointer removes the value addressed by an RFC 6901 JSON Pointer.
+func (n *NestedJson) DeletePointer(ptr string) error {
+	tokens, err := splitPointer(ptr)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("Cannot delete the root document")
+	}
+
+	updated, err := deletePointerRecursive(n.data, tokens)
+	if err != nil {
+		return err
+	}
+	n.data = updated.(map[string]interface{})
+	return nil
+}
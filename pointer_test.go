@@ -0,0 +1,115 @@
+package nestedjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPointer(t *testing.T) {
+	var testPointers = []struct {
+		ptr   string
+		parts []string
+	}{
+		{"", nil},
+		{"/foo", []string{"foo"}},
+		{"/foo/0/bar", []string{"foo", "0", "bar"}},
+		{"/a~1b", []string{"a/b"}},
+		{"/a~0b", []string{"a~b"}},
+		{"/", []string{""}},
+	}
+
+	for _, item := range testPointers {
+		parts, err := splitPointer(item.ptr)
+		assert.Nil(t, err)
+		assert.Equal(t, item.parts, parts)
+	}
+
+	_, err := splitPointer("foo")
+	assert.Error(t, err)
+}
+
+func TestGetPointer(t *testing.T) {
+	json := getTestJson(t, "s2")
+
+	v, err := json.GetPointer("")
+	assert.NoError(t, err)
+	assert.Equal(t, json.data, v)
+
+	v, err = json.GetPointer("/a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, "moo", v)
+
+	v, err = json.GetPointer("/c/0")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, v)
+
+	v, err = json.GetPointer("/d/1/a")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, v)
+
+	_, err = json.GetPointer("/c/10")
+	assert.Error(t, err)
+
+	_, err = json.GetPointer("/missing")
+	assert.Error(t, err)
+}
+
+func TestGetPointerNumericKey(t *testing.T) {
+	json, err := DecodeStr(`{"0": "zero", "arr": [10, 20]}`)
+	assert.NoError(t, err)
+
+	v, err := json.GetPointer("/0")
+	assert.NoError(t, err)
+	assert.Equal(t, "zero", v)
+
+	v, err = json.GetPointer("/arr/1")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 20, v)
+}
+
+func TestSetPointer(t *testing.T) {
+	json := New()
+
+	err := json.SetPointer("/a/b", "moo")
+	assert.NoError(t, err)
+	v, err := json.GetPointer("/a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, "moo", v)
+
+	err = json.SetPointer("/list", []interface{}{1, 2, 3})
+	assert.NoError(t, err)
+
+	err = json.SetPointer("/list/0", 100)
+	assert.NoError(t, err)
+	v, err = json.GetPointer("/list/0")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 100, v)
+
+	err = json.SetPointer("/list/-", 4)
+	assert.NoError(t, err)
+	v, err = json.GetPointer("/list/3")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, v)
+}
+
+func TestDeletePointer(t *testing.T) {
+	json := getTestJson(t, "s2")
+
+	err := json.DeletePointer("/b")
+	assert.NoError(t, err)
+	_, err = json.Get("b")
+	assert.Error(t, err)
+
+	err = json.DeletePointer("/c/0")
+	assert.NoError(t, err)
+	v, err := json.GetPointer("/c")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{2.0, 3.0}, v)
+
+	err = json.DeletePointer("")
+	assert.Error(t, err)
+
+	err = json.DeletePointer("/missing")
+	assert.Error(t, err)
+}